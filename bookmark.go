@@ -0,0 +1,197 @@
+package windowslogging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultRingBufferSize bounds how many un-delivered events the tailing goroutine
+// holds in memory before it starts dropping the oldest entries.
+const defaultRingBufferSize = 1000
+
+// bookmarkState is persisted to disk so a restart resumes from the last delivered
+// event instead of re-reading the whole channel from the start.
+type bookmarkState struct {
+	Channel       string `json:"channel"`
+	EventRecordID uint64 `json:"event_record_id"`
+}
+
+// tailRing is a small bounded ring buffer of events collected by the tailing
+// goroutine between Readings calls. Once full, the oldest event is dropped to make
+// room for the newest.
+type tailRing struct {
+	mu       sync.Mutex
+	events   []map[string]interface{}
+	capacity int
+}
+
+func newTailRing(capacity int) *tailRing {
+	if capacity <= 0 {
+		capacity = defaultRingBufferSize
+	}
+	return &tailRing{capacity: capacity}
+}
+
+func (r *tailRing) push(event map[string]interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	if len(r.events) > r.capacity {
+		r.events = r.events[len(r.events)-r.capacity:]
+	}
+}
+
+// drain returns and clears all buffered events.
+func (r *tailRing) drain() []map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := r.events
+	r.events = nil
+	return out
+}
+
+func bookmarkPath(stateDir, channel string) string {
+	// Sanitize the full channel rather than truncating to its last path segment:
+	// distinct channels that share a suffix (e.g. two "*/Operational" logs) would
+	// otherwise collide on the same bookmark file.
+	safeChannel := strings.ReplaceAll(channel, "/", "_")
+	return filepath.Join(stateDir, fmt.Sprintf("bookmark-%s.json", safeChannel))
+}
+
+func loadBookmark(stateDir, channel string) (bookmarkState, error) {
+	state := bookmarkState{Channel: channel}
+	data, err := os.ReadFile(bookmarkPath(stateDir, channel))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse bookmark file: %w", err)
+	}
+	return state, nil
+}
+
+func saveBookmark(stateDir string, state bookmarkState) error {
+	if err := os.MkdirAll(stateDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(bookmarkPath(stateDir, state.Channel), data, 0o644)
+}
+
+// startTailing launches the background subscription goroutine that keeps the ring
+// buffer topped up between Readings calls, persisting its bookmark as it goes. It
+// is owned by s and tied to s.cancelCtx, so it exits when the sensor is closed.
+func (s *windowsLoggingLogging) startTailing() {
+	channel := s.cfg.EventChannel
+	if channel == "" {
+		channel = s.cfg.LogType
+	}
+
+	state, err := loadBookmark(s.cfg.StateDir, channel)
+	if err != nil {
+		s.logger.Warnf("windows-logging: failed to load bookmark for %s, starting fresh: %v", channel, err)
+	}
+	s.bookmarkMu.Lock()
+	s.bookmark = state
+	s.bookmarkMu.Unlock()
+
+	go s.tailLoop(s.cancelCtx, channel)
+}
+
+// tailLoop keeps a single long-lived EvtSubscribe subscription open for the life
+// of ctx and drains it on an interval, appending new records into the ring buffer
+// and advancing the bookmark. When set_filter changes the query, the subscription
+// is reopened after the current bookmark with the new query.
+func (s *windowsLoggingLogging) tailLoop(ctx context.Context, channel string) {
+	cfg := s.cfgSnapshot()
+	subHandle, signalEvent, bookmarkHandle, err := openSubscription(cfg, s.logger, s.getBookmark())
+	if err != nil {
+		s.logger.Errorf("windows-logging: failed to open tailing subscription for %s: %v", channel, err)
+		return
+	}
+	query := buildXPathQuery(cfg)
+	defer func() { closeSubscription(subHandle, signalEvent, bookmarkHandle) }()
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cfg := s.cfgSnapshot()
+			if q := buildXPathQuery(cfg); q != query {
+				closeSubscription(subHandle, signalEvent, bookmarkHandle)
+				subHandle, signalEvent, bookmarkHandle, err = openSubscription(cfg, s.logger, s.getBookmark())
+				if err != nil {
+					s.logger.Errorf("windows-logging: failed to reopen tailing subscription for %s: %v", channel, err)
+					return
+				}
+				query = q
+			}
+			entries, err := drainSubscription(subHandle, cfg, s.logger, s.stats)
+			if err != nil {
+				s.logger.Errorf("windows-logging: tail poll failed for %s: %v", channel, err)
+				continue
+			}
+
+			s.bookmarkMu.Lock()
+			lastID := s.bookmark.EventRecordID
+			newest := lastID
+			for _, entry := range entries {
+				recordID, _ := entry["EventRecordID"].(uint64)
+				if recordID <= lastID {
+					continue
+				}
+				s.tailBuf.push(entry)
+				if s.exportBuf != nil {
+					s.exportBuf.push(entry)
+				}
+				if recordID > newest {
+					newest = recordID
+				}
+			}
+			if newest != lastID {
+				s.bookmark.EventRecordID = newest
+				s.bookmark.Channel = channel
+				if err := saveBookmark(s.cfg.StateDir, s.bookmark); err != nil {
+					s.logger.Warnf("windows-logging: failed to persist bookmark: %v", err)
+				}
+			}
+			s.bookmarkMu.Unlock()
+		}
+	}
+}
+
+// resetBookmark clears the in-memory and persisted bookmark. The running
+// subscription is not reopened; a subscription opened afterwards with no stored
+// record ID watches only for events logged from then on.
+func (s *windowsLoggingLogging) resetBookmark() error {
+	channel := s.cfg.EventChannel
+	if channel == "" {
+		channel = s.cfg.LogType
+	}
+	s.bookmarkMu.Lock()
+	s.bookmark = bookmarkState{Channel: channel}
+	s.bookmarkMu.Unlock()
+	return saveBookmark(s.cfg.StateDir, bookmarkState{Channel: channel})
+}
+
+func (s *windowsLoggingLogging) getBookmark() bookmarkState {
+	s.bookmarkMu.Lock()
+	defer s.bookmarkMu.Unlock()
+	return s.bookmark
+}
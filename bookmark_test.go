@@ -0,0 +1,58 @@
+package windowslogging
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBookmarkPathSanitizesChannelSeparators(t *testing.T) {
+	got := bookmarkPath("/tmp/state", "Microsoft-Windows-PowerShell/Operational")
+	want := filepath.Join("/tmp/state", "bookmark-Microsoft-Windows-PowerShell_Operational.json")
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestBookmarkPathNoLongerCollidesOnSharedSuffix(t *testing.T) {
+	a := bookmarkPath("/tmp/state", "Microsoft-Windows-PowerShell/Operational")
+	b := bookmarkPath("/tmp/state", "Microsoft-Windows-TaskScheduler/Operational")
+	if a == b {
+		t.Fatalf("expected distinct channels sharing a suffix to get distinct bookmark paths, both got %q", a)
+	}
+}
+
+func TestTailRingDrainReturnsAndClearsEvents(t *testing.T) {
+	r := newTailRing(10)
+	r.push(map[string]interface{}{"EventID": 1})
+	r.push(map[string]interface{}{"EventID": 2})
+
+	got := r.drain()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+	if len(r.drain()) != 0 {
+		t.Fatal("expected drain to clear the buffer")
+	}
+}
+
+func TestTailRingDropsOldestOnceFull(t *testing.T) {
+	r := newTailRing(2)
+	r.push(map[string]interface{}{"EventID": 1})
+	r.push(map[string]interface{}{"EventID": 2})
+	r.push(map[string]interface{}{"EventID": 3})
+
+	got := r.drain()
+	if len(got) != 2 {
+		t.Fatalf("expected capacity to cap the buffer at 2 events, got %d", len(got))
+	}
+	if got[0]["EventID"] != 2 || got[1]["EventID"] != 3 {
+		t.Fatalf("expected the oldest event to be dropped, got %+v", got)
+	}
+}
+
+func TestTailRingDefaultsCapacityWhenNonPositive(t *testing.T) {
+	r := newTailRing(0)
+	if r.capacity != defaultRingBufferSize {
+		t.Fatalf("got capacity %d, want %d", r.capacity, defaultRingBufferSize)
+	}
+}
@@ -2,10 +2,11 @@ package main
 
 import (
 	"context"
-	"windowslogging"
+	sensor "go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
-	sensor "go.viam.com/rdk/components/sensor"
+	"windowslogging"
+	"windowslogging/logctx"
 )
 
 func main() {
@@ -18,13 +19,14 @@ func main() {
 func realMain() error {
 	ctx := context.Background()
 	logger := logging.NewLogger("cli")
+	ctx = logctx.NewContextWithLogger(ctx, logger)
 
 	deps := resource.Dependencies{}
 	// can load these from a remote machine if you need
 
 	cfg := windowslogging.Config{}
 
-	thing, err := windowslogging.NewLogging(ctx, deps, sensor.Named("foo"), &cfg, logger)
+	thing, err := windowslogging.NewLogging(ctx, deps, sensor.Named("foo"), &cfg)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,225 @@
+package windowslogging
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/google/winops/winlog/wevtapi"
+	"golang.org/x/sys/windows"
+
+	"go.viam.com/rdk/logging"
+)
+
+// eventLevelNumbers maps the human-readable level names accepted in Config to the
+// numeric Windows Event Log "Level" values used in XPath filters.
+var eventLevelNumbers = map[string]int{
+	"CRITICAL": 1,
+	"ERROR":    2,
+	"WARNING":  3,
+	"INFO":     4,
+	"VERBOSE":  5,
+}
+
+// eventXML mirrors the subset of the rendered event XML we care about. The Windows
+// Event Log XML schema nests the interesting fields under System and EventData.
+type eventXML struct {
+	System struct {
+		Provider struct {
+			Name string `xml:"Name,attr"`
+		} `xml:"Provider"`
+		EventID       int    `xml:"EventID"`
+		Level         int    `xml:"Level"`
+		EventRecordID uint64 `xml:"EventRecordID"`
+		TimeCreated   struct {
+			SystemTime string `xml:"SystemTime,attr"`
+		} `xml:"TimeCreated"`
+	} `xml:"System"`
+	EventData struct {
+		Data []eventDataItem `xml:"Data"`
+	} `xml:"EventData"`
+}
+
+// eventDataItem is one Name/Value pair nested under an event's EventData element.
+type eventDataItem struct {
+	Name  string `xml:"Name,attr"`
+	Value string `xml:",chardata"`
+}
+
+// buildXPathQuery turns EventLevel/EventIDs into a QueryList XPath filter, or returns
+// the user-supplied XPathQuery unchanged if one was given.
+func buildXPathQuery(cfg *Config) string {
+	if cfg.XPathQuery != "" {
+		return cfg.XPathQuery
+	}
+
+	var conds []string
+	if cfg.EventLevel != "" {
+		conds = append(conds, fmt.Sprintf("Level=%d", eventLevelNumbers[strings.ToUpper(cfg.EventLevel)]))
+	}
+
+	var idConds []string
+	for _, id := range cfg.EventIDs {
+		idConds = append(idConds, fmt.Sprintf("EventID=%d", id))
+	}
+	if len(idConds) > 0 {
+		conds = append(conds, "("+strings.Join(idConds, " or ")+")")
+	}
+
+	selector := "*"
+	if len(conds) > 0 {
+		selector = fmt.Sprintf("*[System[%s]]", strings.Join(conds, " and "))
+	}
+
+	channel := cfg.EventChannel
+	if channel == "" {
+		channel = cfg.LogType
+	}
+
+	return fmt.Sprintf(`<QueryList><Query Id="0"><Select Path="%s">%s</Select></Query></QueryList>`, channel, selector)
+}
+
+// bookmarkXML renders the standard Windows Event Log bookmark XML pinning channel at
+// recordID, suitable for EvtCreateBookmark.
+func bookmarkXML(channel string, recordID uint64) string {
+	replacer := strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;", `"`, "&quot;")
+	return fmt.Sprintf(`<BookmarkList><Bookmark Channel="%s" RecordId="%d" IsCurrent="true"/></BookmarkList>`,
+		replacer.Replace(channel), recordID)
+}
+
+// openSubscription creates a long-lived EvtSubscribe handle on channel/query, meant
+// to be polled repeatedly via drainSubscription rather than re-created per poll. When
+// bookmark has a persisted EventRecordID, the subscription resumes just after it via
+// EvtSubscribeStartAfterBookmark instead of only watching for future events, so a
+// restart doesn't lose everything logged while the process was down.
+func openSubscription(cfg *Config, logger logging.Logger, bookmark bookmarkState) (subHandle uintptr, signalEvent windows.Handle, bookmarkHandle uintptr, err error) {
+	channel := cfg.EventChannel
+	if channel == "" {
+		channel = cfg.LogType
+	}
+	query := buildXPathQuery(cfg)
+
+	flags := uint32(wevtapi.EvtSubscribeToFutureEvents)
+	if bookmark.EventRecordID > 0 {
+		bookmarkHandle, err = wevtapi.EvtCreateBookmark(bookmarkXML(channel, bookmark.EventRecordID))
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("failed to create bookmark handle for %s at record %d: %w", channel, bookmark.EventRecordID, err)
+		}
+		flags = wevtapi.EvtSubscribeStartAfterBookmark
+	}
+
+	logger.Infof("windows-logging: subscribing to channel=%s query=%s from_bookmark=%v", channel, query, bookmark.EventRecordID > 0)
+
+	signalEvent, err = windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		if bookmarkHandle != 0 {
+			wevtapi.EvtClose(bookmarkHandle)
+		}
+		return 0, 0, 0, fmt.Errorf("failed to create subscription signal event: %w", err)
+	}
+
+	subHandle, err = wevtapi.EvtSubscribe(
+		0, uintptr(signalEvent), channel, query,
+		bookmarkHandle, 0, 0, flags,
+	)
+	if err != nil {
+		windows.CloseHandle(signalEvent)
+		if bookmarkHandle != 0 {
+			wevtapi.EvtClose(bookmarkHandle)
+		}
+		return 0, 0, 0, fmt.Errorf("EvtSubscribe failed for channel %s: %w", channel, err)
+	}
+	return subHandle, signalEvent, bookmarkHandle, nil
+}
+
+func closeSubscription(subHandle uintptr, signalEvent windows.Handle, bookmarkHandle uintptr) {
+	wevtapi.EvtClose(subHandle)
+	windows.CloseHandle(signalEvent)
+	if bookmarkHandle != 0 {
+		wevtapi.EvtClose(bookmarkHandle)
+	}
+}
+
+// renderMessage joins EventData values into the same rendered-message shape
+// Get-EventLog's Message field takes, so log_filter's message-body match behaves the
+// same regardless of which path produced the entry.
+func renderMessage(data []eventDataItem) string {
+	parts := make([]string, 0, len(data))
+	for _, d := range data {
+		parts = append(parts, d.Value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// drainSubscription pulls whatever events have queued up on subHandle since the
+// last call, parses and filters them, and updates stats. It does not close
+// subHandle, so the same subscription can be drained on every tick of tailLoop.
+func drainSubscription(subHandle uintptr, cfg *Config, logger logging.Logger, stats *moduleStats) ([]map[string]interface{}, error) {
+	source := cfg.EventChannel
+	if source == "" {
+		source = cfg.LogType
+	}
+
+	raws, err := wevtapi.EvtNextChunk(subHandle)
+	if err != nil {
+		stats.setLastError(err)
+		return nil, fmt.Errorf("EvtNext failed: %w", err)
+	}
+
+	entries := make([]map[string]interface{}, 0, len(raws))
+	for _, raw := range raws {
+		var parsed eventXML
+		if err := xml.Unmarshal([]byte(raw), &parsed); err != nil {
+			stats.incParseErrors(source)
+			logger.Warnf("windows-logging: failed to unmarshal event XML: %v", err)
+			continue
+		}
+		eventData := make(map[string]string, len(parsed.EventData.Data))
+		for _, d := range parsed.EventData.Data {
+			eventData[d.Name] = d.Value
+		}
+		entries = append(entries, map[string]interface{}{
+			"TimeGenerated": parsed.System.TimeCreated.SystemTime,
+			"Source":        parsed.System.Provider.Name,
+			"EventID":       parsed.System.EventID,
+			"EntryType":     parsed.System.Level,
+			"EventRecordID": parsed.System.EventRecordID,
+			"Message":       renderMessage(parsed.EventData.Data),
+			"EventData":     eventData,
+		})
+	}
+
+	entries, err = filterEntries(entries, cfg.LogFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.addEventsRead(source, len(entries))
+	return entries, nil
+}
+
+// readLiveEventLog opens a subscription, drains whatever is immediately available,
+// and tears it back down. Used by the one-shot "query" DoCommand verb; tailLoop
+// instead keeps a subscription open across polls.
+func readLiveEventLog(cfg *Config, logger logging.Logger, stats *moduleStats) (map[string]interface{}, error) {
+	subHandle, signalEvent, bookmarkHandle, err := openSubscription(cfg, logger, bookmarkState{})
+	if err != nil {
+		return nil, err
+	}
+	defer closeSubscription(subHandle, signalEvent, bookmarkHandle)
+
+	entries, err := drainSubscription(subHandle, cfg, logger, stats)
+	if err != nil {
+		logger.Errorf("windows-logging: %v", err)
+		return map[string]interface{}{
+			"state":  "error",
+			"error":  err.Error(),
+			"source": cfg.EventChannel,
+		}, nil
+	}
+
+	return map[string]interface{}{
+		"state":        "live_mode",
+		"windows_logs": entries,
+	}, nil
+}
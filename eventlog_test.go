@@ -0,0 +1,50 @@
+package windowslogging
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildXPathQueryUserSuppliedPassesThrough(t *testing.T) {
+	cfg := &Config{XPathQuery: "*[System[EventID=4624]]", EventChannel: "Security"}
+	got := buildXPathQuery(cfg)
+	if got != cfg.XPathQuery {
+		t.Fatalf("expected user-supplied XPathQuery to pass through unchanged, got %q", got)
+	}
+}
+
+func TestBuildXPathQueryDefaultSelectsEverything(t *testing.T) {
+	cfg := &Config{EventChannel: "Application"}
+	got := buildXPathQuery(cfg)
+	want := `<QueryList><Query Id="0"><Select Path="Application">*</Select></Query></QueryList>`
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestBuildXPathQueryLevelAndEventIDs(t *testing.T) {
+	cfg := &Config{
+		EventChannel: "Microsoft-Windows-PowerShell/Operational",
+		EventLevel:   "error",
+		EventIDs:     []int{4103, 4104},
+	}
+	got := buildXPathQuery(cfg)
+
+	if !strings.Contains(got, `Level=2`) {
+		t.Fatalf("expected query to filter on Level=2 for ERROR, got %q", got)
+	}
+	if !strings.Contains(got, "EventID=4103 or EventID=4104") {
+		t.Fatalf("expected query to OR the configured event IDs, got %q", got)
+	}
+	if !strings.Contains(got, `Path="Microsoft-Windows-PowerShell/Operational"`) {
+		t.Fatalf("expected query to select the configured channel, got %q", got)
+	}
+}
+
+func TestBuildXPathQueryFallsBackToLogType(t *testing.T) {
+	cfg := &Config{LogType: "System"}
+	got := buildXPathQuery(cfg)
+	if !strings.Contains(got, `Path="System"`) {
+		t.Fatalf("expected query to fall back to LogType when EventChannel is unset, got %q", got)
+	}
+}
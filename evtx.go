@@ -0,0 +1,58 @@
+package windowslogging
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/google/winops/winlog/wevtapi"
+
+	"go.viam.com/rdk/logging"
+)
+
+// parseEvtxFile reads every record out of a captured .evtx file and renders it into
+// the same shape readLiveEventLog produces, so incident-response archives and CI
+// fixtures can replay through the same sensor pipeline as a live host. A record that
+// fails to unmarshal is logged and skipped, like drainSubscription does, rather than
+// discarding the rest of the file.
+func parseEvtxFile(filePath string, logger logging.Logger, stats *moduleStats) ([]map[string]interface{}, error) {
+	queryHandle, err := wevtapi.EvtQuery(0, filePath, "", wevtapi.EvtQueryFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open evtx file %s: %w", filePath, err)
+	}
+	defer wevtapi.EvtClose(queryHandle)
+
+	var entries []map[string]interface{}
+	for {
+		raws, err := wevtapi.EvtNextChunk(queryHandle)
+		if err != nil || len(raws) == 0 {
+			break
+		}
+
+		for _, raw := range raws {
+			var parsed eventXML
+			if err := xml.Unmarshal([]byte(raw), &parsed); err != nil {
+				stats.incParseErrors(filePath)
+				logger.Warnf("windows-logging: failed to unmarshal evtx record XML: %v", err)
+				continue
+			}
+
+			eventData := make(map[string]string, len(parsed.EventData.Data))
+			for _, d := range parsed.EventData.Data {
+				eventData[d.Name] = d.Value
+			}
+
+			entries = append(entries, map[string]interface{}{
+				"TimeGenerated": parsed.System.TimeCreated.SystemTime,
+				"Source":        parsed.System.Provider.Name,
+				"EventID":       parsed.System.EventID,
+				"EntryType":     parsed.System.Level,
+				"EventRecordID": parsed.System.EventRecordID,
+				"Message":       renderMessage(parsed.EventData.Data),
+				"EventData":     eventData,
+			})
+		}
+	}
+
+	stats.addEventsRead(filePath, len(entries))
+	return entries, nil
+}
@@ -0,0 +1,44 @@
+// Package export provides pluggable output sinks for batches of Windows event log
+// entries, so the module can act as a forwarder (draining its internal buffer to
+// durable storage or a collector) in addition to answering Readings pulls.
+package export
+
+import (
+	"context"
+	"fmt"
+)
+
+// Event is a single log entry, in the same loosely-typed shape Readings returns.
+type Event map[string]interface{}
+
+// Exporter drains a batch of events to some destination. Implementations should be
+// safe to call repeatedly on an interval and should not block indefinitely; callers
+// are expected to pass a context with a reasonable per-batch timeout.
+type Exporter interface {
+	Export(ctx context.Context, events []Event) error
+	Close() error
+}
+
+// Config selects and configures one Exporter. Exactly one of the type-specific
+// blocks should be populated, matching cfg.Type.
+type Config struct {
+	Type string `json:"type"` // "file", "s3", or "http"
+
+	File FileConfig `json:"file"`
+	S3   S3Config   `json:"s3"`
+	HTTP HTTPConfig `json:"http"`
+}
+
+// New builds the Exporter described by cfg.
+func New(cfg Config) (Exporter, error) {
+	switch cfg.Type {
+	case "file":
+		return NewFileExporter(cfg.File)
+	case "s3":
+		return NewS3Exporter(cfg.S3)
+	case "http":
+		return NewHTTPExporter(cfg.HTTP)
+	default:
+		return nil, fmt.Errorf("export: unsupported exporter type %q", cfg.Type)
+	}
+}
@@ -0,0 +1,108 @@
+package export
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileConfig configures the rotating gzip'd NDJSON file sink.
+type FileConfig struct {
+	// Dir is the sweep directory new files are written into.
+	Dir string `json:"dir"`
+	// MaxEventsPerFile rotates to a new file once the current one holds this many
+	// events. Defaults to 10000.
+	MaxEventsPerFile int `json:"max_events_per_file"`
+}
+
+// fileExporter appends events as gzip'd NDJSON into a rotating file under Dir. This
+// follows the directory-sweep uploader pattern: files are named by start time, and
+// a downstream sweeper (or the S3/HTTP sinks) can pick up completed files.
+type fileExporter struct {
+	cfg FileConfig
+
+	mu           sync.Mutex
+	file         *os.File
+	gz           *gzip.Writer
+	enc          *json.Encoder
+	eventsInFile int
+}
+
+// NewFileExporter builds a rotating gzip'd NDJSON file exporter.
+func NewFileExporter(cfg FileConfig) (Exporter, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("export: file exporter requires a dir")
+	}
+	if cfg.MaxEventsPerFile <= 0 {
+		cfg.MaxEventsPerFile = 10000
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("export: failed to create sweep dir: %w", err)
+	}
+	return &fileExporter{cfg: cfg}, nil
+}
+
+func (e *fileExporter) Export(ctx context.Context, events []Event) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, ev := range events {
+		if e.enc == nil || e.eventsInFile >= e.cfg.MaxEventsPerFile {
+			if err := e.rotateLocked(); err != nil {
+				return err
+			}
+		}
+		if err := e.enc.Encode(ev); err != nil {
+			return fmt.Errorf("export: failed to write event: %w", err)
+		}
+		e.eventsInFile++
+	}
+	return nil
+}
+
+// rotateLocked closes the current file, if any, and opens a new one. Callers must
+// hold e.mu.
+func (e *fileExporter) rotateLocked() error {
+	if err := e.closeCurrentLocked(); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("events-%d.ndjson.gz", time.Now().UnixNano())
+	f, err := os.Create(filepath.Join(e.cfg.Dir, name))
+	if err != nil {
+		return fmt.Errorf("export: failed to create sweep file: %w", err)
+	}
+	gz := gzip.NewWriter(f)
+	e.file = f
+	e.gz = gz
+	e.enc = json.NewEncoder(gz)
+	e.eventsInFile = 0
+	return nil
+}
+
+func (e *fileExporter) closeCurrentLocked() error {
+	if e.gz == nil {
+		return nil
+	}
+	if err := e.gz.Close(); err != nil {
+		return fmt.Errorf("export: failed to flush gzip writer: %w", err)
+	}
+	if err := e.file.Close(); err != nil {
+		return fmt.Errorf("export: failed to close sweep file: %w", err)
+	}
+	e.gz = nil
+	e.file = nil
+	e.enc = nil
+	return nil
+}
+
+func (e *fileExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.closeCurrentLocked()
+}
@@ -0,0 +1,89 @@
+package export
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileExporterRotatesAtMaxEventsPerFile(t *testing.T) {
+	dir := t.TempDir()
+	exp, err := NewFileExporter(FileConfig{Dir: dir, MaxEventsPerFile: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := []Event{{"EventID": 1}, {"EventID": 2}, {"EventID": 3}}
+	if err := exp.Export(context.Background(), events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := exp.Close(); err != nil {
+		t.Fatalf("unexpected error closing exporter: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error reading sweep dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected rotation to produce 2 files for 3 events at MaxEventsPerFile=2, got %d", len(entries))
+	}
+}
+
+func TestFileExporterWritesValidGzippedNDJSON(t *testing.T) {
+	dir := t.TempDir()
+	exp, err := NewFileExporter(FileConfig{Dir: dir, MaxEventsPerFile: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := []Event{{"EventID": 1}, {"EventID": 2}}
+	if err := exp.Export(context.Background(), events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := exp.Close(); err != nil {
+		t.Fatalf("unexpected error closing exporter: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error reading sweep dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected a single sweep file, got %d", len(entries))
+	}
+
+	f, err := os.Open(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatalf("unexpected error opening sweep file: %v", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("sweep file is not valid gzip: %v", err)
+	}
+	defer gz.Close()
+
+	dec := json.NewDecoder(gz)
+	var got int
+	for {
+		var ev Event
+		if err := dec.Decode(&ev); err != nil {
+			break
+		}
+		got++
+	}
+	if got != len(events) {
+		t.Fatalf("expected %d decoded events, got %d", len(events), got)
+	}
+}
+
+func TestNewFileExporterRequiresDir(t *testing.T) {
+	if _, err := NewFileExporter(FileConfig{}); err == nil {
+		t.Fatal("expected an error when dir is empty")
+	}
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPConfig configures the generic HTTPS POST sink.
+type HTTPConfig struct {
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers"`
+	MaxRetries int               `json:"max_retries"` // defaults to 3
+}
+
+// httpExporter POSTs each batch as a single JSON array body, retrying with
+// exponential backoff on transport errors or 5xx responses.
+type httpExporter struct {
+	cfg    HTTPConfig
+	client *http.Client
+}
+
+// NewHTTPExporter builds a retrying HTTPS POST exporter.
+func NewHTTPExporter(cfg HTTPConfig) (Exporter, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("export: http exporter requires a url")
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = 3
+	}
+	return &httpExporter{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (e *httpExporter) Export(ctx context.Context, events []Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("export: failed to marshal batch: %w", err)
+	}
+
+	var lastErr error
+	backoff := 500 * time.Millisecond
+	for attempt := 0; attempt <= e.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.URL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("export: failed to build request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range e.cfg.Headers {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := e.client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("export: http sink returned %s", resp.Status)
+			continue
+		}
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("export: http sink rejected batch: %s", resp.Status)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("export: giving up after %d retries: %w", e.cfg.MaxRetries, lastErr)
+}
+
+func (e *httpExporter) Close() error {
+	return nil
+}
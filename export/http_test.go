@@ -0,0 +1,81 @@
+package export
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPExporterRetriesOn5xxThenSucceeds(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	exp, err := NewHTTPExporter(HTTPConfig{URL: srv.URL, MaxRetries: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := exp.Export(context.Background(), []Event{{"EventID": 1}}); err != nil {
+		t.Fatalf("expected export to succeed after retrying, got: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected exactly 2 attempts, got %d", attempts)
+	}
+}
+
+func TestHTTPExporterGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	exp, err := NewHTTPExporter(HTTPConfig{URL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := exp.Export(context.Background(), []Event{{"EventID": 1}}); err == nil {
+		t.Fatal("expected export to fail after exhausting retries")
+	}
+	if attempts != 2 {
+		t.Fatalf("expected MaxRetries+1 = 2 attempts, got %d", attempts)
+	}
+}
+
+func TestHTTPExporterDoesNotRetryOn4xx(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	exp, err := NewHTTPExporter(HTTPConfig{URL: srv.URL, MaxRetries: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := exp.Export(context.Background(), []Event{{"EventID": 1}}); err == nil {
+		t.Fatal("expected export to fail on a 4xx response")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected a 4xx response to not be retried, got %d attempts", attempts)
+	}
+}
+
+func TestNewHTTPExporterRequiresURL(t *testing.T) {
+	if _, err := NewHTTPExporter(HTTPConfig{}); err == nil {
+		t.Fatal("expected an error when url is empty")
+	}
+}
@@ -0,0 +1,77 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Config configures the S3 multipart upload sink.
+type S3Config struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix"`
+	Region string `json:"region"`
+}
+
+// s3Exporter batches events into a single gzip'd NDJSON object per Export call and
+// uploads it via the S3 multipart upload manager, which handles splitting large
+// batches into parts and retrying failed parts internally.
+type s3Exporter struct {
+	cfg      S3Config
+	uploader *manager.Uploader
+}
+
+// NewS3Exporter builds an S3 multipart-upload exporter.
+func NewS3Exporter(cfg S3Config) (Exporter, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("export: s3 exporter requires a bucket")
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, fmt.Errorf("export: failed to load AWS config: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg)
+	return &s3Exporter{
+		cfg:      cfg,
+		uploader: manager.NewUploader(client),
+	}, nil
+}
+
+func (e *s3Exporter) Export(ctx context.Context, events []Event) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(gz)
+	for _, ev := range events {
+		if err := enc.Encode(ev); err != nil {
+			return fmt.Errorf("export: failed to encode event for s3 upload: %w", err)
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("export: failed to flush gzip writer: %w", err)
+	}
+
+	key := fmt.Sprintf("%s/events-%d.ndjson.gz", e.cfg.Prefix, time.Now().UnixNano())
+	_, err := e.uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(e.cfg.Bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(buf.Bytes()),
+	})
+	if err != nil {
+		return fmt.Errorf("export: s3 upload failed: %w", err)
+	}
+	return nil
+}
+
+func (e *s3Exporter) Close() error {
+	return nil
+}
@@ -0,0 +1,48 @@
+package windowslogging
+
+import (
+	"context"
+	"time"
+
+	"windowslogging/export"
+)
+
+// exportLoop drains exportBuf to every configured sink on an interval. It is owned
+// by s and tied to ctx so it exits when the sensor is closed.
+func (s *windowsLoggingLogging) exportLoop(ctx context.Context) {
+	interval := time.Duration(s.cfg.ExportIntervalSec) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.flushExporters(ctx)
+		}
+	}
+}
+
+// flushExporters drains exportBuf and pushes the batch through every sink. A sink
+// that errors is logged and skipped; the batch is not re-queued.
+func (s *windowsLoggingLogging) flushExporters(ctx context.Context) {
+	if s.exportBuf == nil {
+		return
+	}
+	buffered := s.exportBuf.drain()
+	if len(buffered) == 0 {
+		return
+	}
+
+	events := make([]export.Event, len(buffered))
+	for i, e := range buffered {
+		events[i] = export.Event(e)
+	}
+
+	for _, exp := range s.exporters {
+		if err := exp.Export(ctx, events); err != nil {
+			s.logger.Errorf("windows-logging: exporter failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// Package logctx carries a logging.Logger on a context.Context, so helper
+// functions that are several calls deep don't need a trailing logger argument
+// threaded through every signature.
+package logctx
+
+import (
+	"context"
+
+	"go.viam.com/rdk/logging"
+)
+
+type loggerKey struct{}
+
+// NewContextWithLogger returns a context carrying logger, retrievable with
+// FromContext.
+func NewContextWithLogger(ctx context.Context, logger logging.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or logging.NewLogger("windowslogging")
+// if none was set. It never returns nil, so callers can log unconditionally.
+func FromContext(ctx context.Context) logging.Logger {
+	logger, ok := ctx.Value(loggerKey{}).(logging.Logger)
+	if !ok || logger == nil {
+		return logging.NewLogger("windowslogging")
+	}
+	return logger
+}
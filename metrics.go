@@ -0,0 +1,43 @@
+package windowslogging
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"go.viam.com/rdk/logging"
+)
+
+// startMetricsServer registers stats' counters on a fresh registry and serves them
+// at /metrics on addr, returning the server so the caller can shut it down in
+// Close. Started from NewLogging only when Config.PrometheusAddr is set.
+func startMetricsServer(addr string, stats *moduleStats, logger logging.Logger) (*http.Server, error) {
+	registry := prometheus.NewRegistry()
+	for _, c := range stats.collectors() {
+		if err := registry.Register(c); err != nil {
+			return nil, fmt.Errorf("failed to register metrics collector: %w", err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	server := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("windows-logging: metrics server failed: %v", err)
+		}
+	}()
+
+	return server, nil
+}
+
+func stopMetricsServer(ctx context.Context, server *http.Server) error {
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
@@ -6,14 +6,20 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 
 	"go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
+
+	"windowslogging/export"
+	"windowslogging/logctx"
 )
 
 var (
@@ -31,7 +37,43 @@ func init() {
 type Config struct {
 	LogType    string `json:"log_type"`    // e.g., "Application", "System"
 	MaxEntries int    `json:"max_entries"` // how many recent entries to return
-	Logs       string `json:"logs"`        // "test" or path to file (e.g., example_logs/SawHandpieceLog.json)
+	Logs       string `json:"logs"`        // "test" or path to a .csv/.json/.evtx file (e.g., example_logs/SawHandpieceLog.json)
+
+	// EventChannel is the Windows Event Log channel to subscribe to, e.g. "Security"
+	// or "Microsoft-Windows-PowerShell/Operational". When set, Readings uses the
+	// native EvtSubscribe API instead of shelling out to Get-EventLog.
+	EventChannel string `json:"event_channel"`
+	// EventLevel filters by severity: "CRITICAL", "ERROR", "WARNING", "INFO", or "VERBOSE".
+	EventLevel string `json:"event_level"`
+	// EventIDs restricts the subscription to the given event IDs. Ignored if XPathQuery is set.
+	EventIDs []int `json:"event_ids"`
+	// XPathQuery, when non-empty, is passed to EvtSubscribe unchanged instead of the
+	// query built from EventLevel/EventIDs.
+	XPathQuery string `json:"xpath_query"`
+
+	// StateDir is where the tailing bookmark is persisted so a restart resumes
+	// from the last delivered event instead of re-reading the channel from
+	// scratch. Defaults to a "windows-logging" folder under the OS temp dir.
+	StateDir string `json:"state_dir"`
+
+	// Exporters are output sinks the tailing buffer is swept to on an interval, in
+	// addition to being returned through Readings. Leave empty to stay pull-only.
+	Exporters []export.Config `json:"exporters"`
+	// ExportIntervalSec controls how often buffered events are swept to Exporters.
+	// Defaults to 30.
+	ExportIntervalSec int `json:"export_interval_sec"`
+
+	// LogLevel controls this module's own log verbosity: "debug", "info", "warn",
+	// or "error". Defaults to "info".
+	LogLevel string `json:"log_level"`
+	// LogFilter, if set, is a regex matched against each event's Source and
+	// Message; matching events are dropped before being emitted, in both live and
+	// test paths. Useful for silencing chatty sources like "SceCli".
+	LogFilter string `json:"log_filter"`
+
+	// PrometheusAddr, if set (e.g. ":9115"), serves the module's counters at
+	// /metrics on this address for the lifetime of the sensor.
+	PrometheusAddr string `json:"prometheus_addr"`
 }
 
 func (cfg *Config) Validate(path string) ([]string, []string, error) {
@@ -44,9 +86,52 @@ func (cfg *Config) Validate(path string) ([]string, []string, error) {
 	if cfg.Logs == "" {
 		cfg.Logs = "live" // default
 	}
+	if cfg.EventLevel != "" {
+		if _, ok := eventLevelNumbers[strings.ToUpper(cfg.EventLevel)]; !ok {
+			return nil, nil, fmt.Errorf("invalid event_level %q: must be one of CRITICAL, ERROR, WARNING, INFO, VERBOSE", cfg.EventLevel)
+		}
+	}
+	if cfg.StateDir == "" {
+		cfg.StateDir = filepath.Join(os.TempDir(), "windows-logging")
+	}
+	if cfg.ExportIntervalSec <= 0 {
+		cfg.ExportIntervalSec = 30
+	}
+	if cfg.LogLevel == "" {
+		cfg.LogLevel = "info"
+	}
+	switch strings.ToLower(cfg.LogLevel) {
+	case "debug", "info", "warn", "error":
+	default:
+		return nil, nil, fmt.Errorf("invalid log_level %q: must be one of debug, info, warn, error", cfg.LogLevel)
+	}
+	if cfg.LogFilter != "" {
+		if _, err := regexp.Compile(cfg.LogFilter); err != nil {
+			return nil, nil, fmt.Errorf("invalid log_filter regex: %w", err)
+		}
+	}
+	if len(cfg.Exporters) > 0 && cfg.EventChannel == "" && cfg.XPathQuery == "" {
+		return nil, nil, fmt.Errorf("exporters configured but neither event_channel nor xpath_query is set: " +
+			"exporters only drain events collected by live tailing, so they would never fire")
+	}
 	return nil, nil, nil
 }
 
+// logLevelFromConfig maps the validated LogLevel string to logging.Level so
+// NewLogging can apply it to the logger it was handed.
+func logLevelFromConfig(level string) logging.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return logging.DEBUG
+	case "warn":
+		return logging.WARN
+	case "error":
+		return logging.ERROR
+	default:
+		return logging.INFO
+	}
+}
+
 type windowsLoggingLogging struct {
 	resource.AlwaysRebuild
 
@@ -56,6 +141,30 @@ type windowsLoggingLogging struct {
 
 	cancelCtx  context.Context
 	cancelFunc context.CancelFunc
+
+	// tailBuf and bookmark back the streaming/tailing mode: tailLoop appends new
+	// events into tailBuf between Readings calls, and bookmark tracks the last
+	// EventRecordID delivered so restarts resume instead of re-reading the channel.
+	tailBuf    *tailRing
+	bookmark   bookmarkState
+	bookmarkMu sync.Mutex
+
+	// exporters are swept on an interval by exportLoop; nil/empty disables
+	// forwarding and the module stays pull-only via Readings. exportBuf mirrors
+	// tailBuf so a drain for Readings doesn't starve the export sweep, or vice
+	// versa.
+	exporters []export.Exporter
+	exportBuf *tailRing
+
+	// stats backs the "stats" DoCommand verb and, when Config.PrometheusAddr is
+	// set, metricsServer's /metrics endpoint.
+	stats *moduleStats
+
+	metricsServer *http.Server
+
+	// filterMu guards cfg.EventIDs, which "set_filter" mutates in place so the
+	// live query picks up the new filter without a config rebuild.
+	filterMu sync.Mutex
 }
 
 func newWindowsLoggingLogging(ctx context.Context, deps resource.Dependencies, rawConf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -63,10 +172,17 @@ func newWindowsLoggingLogging(ctx context.Context, deps resource.Dependencies, r
 	if err != nil {
 		return nil, err
 	}
-	return NewLogging(ctx, deps, rawConf.ResourceName(), conf, logger)
+	ctx = logctx.NewContextWithLogger(ctx, logger)
+	return NewLogging(ctx, deps, rawConf.ResourceName(), conf)
 }
 
-func NewLogging(ctx context.Context, deps resource.Dependencies, name resource.Name, conf *Config, logger logging.Logger) (sensor.Sensor, error) {
+// NewLogging builds the sensor directly, for callers outside the module
+// registration path (e.g. the standalone CLI). ctx should carry its logger via
+// logctx.NewContextWithLogger; if none was set, logctx.FromContext falls back to a
+// default logger.
+func NewLogging(ctx context.Context, deps resource.Dependencies, name resource.Name, conf *Config) (sensor.Sensor, error) {
+	logger := logctx.FromContext(ctx)
+	logger.SetLevel(logLevelFromConfig(conf.LogLevel))
 	cancelCtx, cancelFunc := context.WithCancel(context.Background())
 
 	s := &windowsLoggingLogging{
@@ -75,11 +191,37 @@ func NewLogging(ctx context.Context, deps resource.Dependencies, name resource.N
 		cfg:        conf,
 		cancelCtx:  cancelCtx,
 		cancelFunc: cancelFunc,
+		tailBuf:    newTailRing(defaultRingBufferSize),
+		stats:      newModuleStats(name.String()),
 	}
 
 	logger.Infof("windows-logging: Initialized with configuration: LogType=%s, MaxEntries=%d, Logs=%s",
 		conf.LogType, conf.MaxEntries, conf.Logs)
 
+	if conf.EventChannel != "" || conf.XPathQuery != "" {
+		s.startTailing()
+	}
+
+	for _, expCfg := range conf.Exporters {
+		exp, err := export.New(expCfg)
+		if err != nil {
+			return nil, fmt.Errorf("windows-logging: failed to build exporter: %w", err)
+		}
+		s.exporters = append(s.exporters, exp)
+	}
+	if len(s.exporters) > 0 {
+		s.exportBuf = newTailRing(defaultRingBufferSize)
+		go s.exportLoop(cancelCtx)
+	}
+
+	if conf.PrometheusAddr != "" {
+		server, err := startMetricsServer(conf.PrometheusAddr, s.stats, logger)
+		if err != nil {
+			return nil, fmt.Errorf("windows-logging: failed to start metrics server: %w", err)
+		}
+		s.metricsServer = server
+	}
+
 	return s, nil
 }
 
@@ -91,26 +233,121 @@ func (s *windowsLoggingLogging) Name() resource.Name {
 func (s *windowsLoggingLogging) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
 	s.logger.Infof("windows-logging: Readings called for %s with config LogType=%s, MaxEntries=%d, Logs=%s",
 		s.name, s.cfg.LogType, s.cfg.MaxEntries, s.cfg.Logs)
+	ctx = logctx.NewContextWithLogger(ctx, s.logger)
 
 	// TEST MODE
-	if s.cfg.Logs == "test" || strings.HasSuffix(s.cfg.Logs, ".csv") || strings.HasSuffix(s.cfg.Logs, ".json") {
+	if s.cfg.Logs == "test" || strings.HasSuffix(s.cfg.Logs, ".csv") || strings.HasSuffix(s.cfg.Logs, ".json") || strings.HasSuffix(s.cfg.Logs, ".evtx") {
 		s.logger.Info("windows-logging: Entering TEST mode")
-		return readTestLogs(s.cfg.Logs, s.logger)
+		return readTestLogs(ctx, s.cfg.Logs, s.cfg.LogFilter, s.stats)
+	}
+
+	// LIVE MODE, tailing: drain events the background subscription has already
+	// collected since the last Readings call instead of re-querying the channel.
+	if s.cfg.EventChannel != "" || s.cfg.XPathQuery != "" {
+		s.logger.Infof("windows-logging: Entering LIVE mode via EvtSubscribe for channel: %s", s.cfg.EventChannel)
+		entries := s.tailBuf.drain()
+		return map[string]interface{}{
+			"state":        "live_mode",
+			"windows_logs": entries,
+			"bookmark":     s.getBookmark(),
+		}, nil
 	}
 
-	// LIVE MODE
 	s.logger.Infof("windows-logging: Entering LIVE mode for log type: %s", s.cfg.LogType)
-	return readLiveLogs(s.cfg.LogType, s.cfg.MaxEntries, s.logger)
+	return readLiveLogs(ctx, s.cfg.LogType, s.cfg.MaxEntries, s.cfg.LogFilter, s.stats)
 }
 
 func (s *windowsLoggingLogging) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
 	s.logger.Infof("windows-logging: DoCommand called with: %+v", cmd)
+
+	verb, _ := cmd["cmd"].(string)
+	switch verb {
+	case "reset_bookmark":
+		if err := s.resetBookmark(); err != nil {
+			return nil, fmt.Errorf("failed to reset bookmark: %w", err)
+		}
+		return map[string]interface{}{"ok": true}, nil
+	case "get_bookmark":
+		bm := s.getBookmark()
+		return map[string]interface{}{
+			"channel":         bm.Channel,
+			"event_record_id": bm.EventRecordID,
+		}, nil
+	case "query":
+		xpath, _ := cmd["xpath"].(string)
+		queryCfg := s.cfgSnapshot()
+		queryCfg.XPathQuery = xpath
+		result, err := readLiveEventLog(queryCfg, s.logger, s.stats)
+		if err != nil {
+			s.stats.setLastError(err)
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		return result, nil
+	case "stats":
+		return s.stats.snapshot(), nil
+	case "set_filter":
+		ids, err := toIntSlice(cmd["event_ids"])
+		if err != nil {
+			return nil, fmt.Errorf("set_filter: %w", err)
+		}
+		s.filterMu.Lock()
+		s.cfg.EventIDs = ids
+		s.filterMu.Unlock()
+		return map[string]interface{}{"ok": true, "event_ids": ids}, nil
+	case "flush":
+		s.flushExporters(ctx)
+		return map[string]interface{}{"ok": true}, nil
+	}
+
 	return nil, fmt.Errorf("DoCommand not implemented")
 }
 
+// cfgSnapshot returns a copy of s.cfg safe to read without further locking,
+// taken under filterMu so it can't observe a torn write from a concurrent
+// set_filter DoCommand. EventIDs is copied rather than aliased so the caller's
+// copy can't be mutated out from under it either.
+func (s *windowsLoggingLogging) cfgSnapshot() *Config {
+	s.filterMu.Lock()
+	defer s.filterMu.Unlock()
+
+	cfgCopy := *s.cfg
+	cfgCopy.EventIDs = append([]int(nil), s.cfg.EventIDs...)
+	return &cfgCopy
+}
+
+// toIntSlice converts the loosely-typed event_ids value DoCommand receives
+// (decoded from JSON as []interface{} of float64) into []int.
+func toIntSlice(raw interface{}) ([]int, error) {
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("event_ids must be an array of numbers")
+	}
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		f, ok := item.(float64)
+		if !ok {
+			return nil, fmt.Errorf("event_ids must be an array of numbers")
+		}
+		ids = append(ids, int(f))
+	}
+	return ids, nil
+}
+
 func (s *windowsLoggingLogging) Close(ctx context.Context) error {
 	s.logger.Infof("windows-logging: Closing sensor %s (LogType=%s, Logs=%s)", s.name, s.cfg.LogType, s.cfg.Logs)
 	s.cancelFunc()
+
+	s.flushExporters(ctx)
+	for _, exp := range s.exporters {
+		if err := exp.Close(); err != nil {
+			s.logger.Warnf("windows-logging: failed to close exporter: %v", err)
+		}
+	}
+
+	if err := stopMetricsServer(ctx, s.metricsServer); err != nil {
+		s.logger.Warnf("windows-logging: failed to shut down metrics server: %v", err)
+	}
+
 	return nil
 }
 
@@ -120,15 +357,17 @@ func (s *windowsLoggingLogging) Close(ctx context.Context) error {
 // ------------------------
 //
 
-// readTestLogs parses test data from CSV or JSON
-func readTestLogs(logPath string, logger logging.Logger) (map[string]interface{}, error) {
+// readTestLogs parses test data from CSV, JSON, or evtx
+func readTestLogs(ctx context.Context, logPath string, logFilter string, stats *moduleStats) (map[string]interface{}, error) {
+	logger := logctx.FromContext(ctx)
+
 	filePath := logPath
 	if logPath == "test" {
 		filePath = "example_logs/000009999-synth 1.csv"
 	}
 
 	logger.Infof("windows-logging: Loading test log file: %s", filePath)
-	data, err := parseTestLogFile(filePath)
+	data, err := parseTestLogFile(ctx, filePath, stats)
 	if err != nil {
 		logger.Errorf("windows-logging: Failed to parse test log file %s: %v", filePath, err)
 		return nil, err
@@ -139,6 +378,11 @@ func readTestLogs(logPath string, logger logging.Logger) (map[string]interface{}
 		return nil, fmt.Errorf("invalid test log data format")
 	}
 
+	testLogs, err = filterEntries(testLogs, logFilter)
+	if err != nil {
+		return nil, err
+	}
+
 	logger.Infof("windows-logging: Successfully read %d test log entries", len(testLogs))
 	return map[string]interface{}{
 		"state": "test_mode",
@@ -147,7 +391,9 @@ func readTestLogs(logPath string, logger logging.Logger) (map[string]interface{}
 }
 
 // readLiveLogs executes a PowerShell command to retrieve recent Windows event logs.
-func readLiveLogs(logType string, maxEntries int, logger logging.Logger) (map[string]interface{}, error) {
+func readLiveLogs(ctx context.Context, logType string, maxEntries int, logFilter string, stats *moduleStats) (map[string]interface{}, error) {
+	logger := logctx.FromContext(ctx)
+	stats.incPowershellInvocations(logType)
 	// PowerShell script: get latest event log entries as JSON
 	psCmd := fmt.Sprintf(
 		`Get-EventLog -LogName %s -Newest %d | Select-Object TimeGenerated, Source, EventID, EntryType, Message | ConvertTo-Json`,
@@ -162,6 +408,7 @@ func readLiveLogs(logType string, maxEntries int, logger logging.Logger) (map[st
 
 	err := cmd.Run()
 	if err != nil {
+		stats.setLastError(err)
 		logger.Errorf("windows-logging: PowerShell command failed: %v (%s)", err, stderr.String())
 		return map[string]interface{}{
 			"state":  "error",
@@ -173,6 +420,8 @@ func readLiveLogs(logType string, maxEntries int, logger logging.Logger) (map[st
 	// ✅ Parse PowerShell JSON output
 	var entries []map[string]interface{}
 	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		stats.incParseErrors(logType)
+		stats.setLastError(err)
 		logger.Errorf("windows-logging: Failed to parse PowerShell output: %v", err)
 		return map[string]interface{}{
 			"state":  "error",
@@ -181,6 +430,12 @@ func readLiveLogs(logType string, maxEntries int, logger logging.Logger) (map[st
 		}, nil
 	}
 
+	entries, err = filterEntries(entries, logFilter)
+	if err != nil {
+		return nil, err
+	}
+	stats.addEventsRead(logType, len(entries))
+
 	// ✅ Marshal back to string for proto-safe transport
 	logsJSON, err := json.Marshal(entries)
 	if err != nil {
@@ -198,8 +453,10 @@ func readLiveLogs(logType string, maxEntries int, logger logging.Logger) (map[st
 	}, nil
 }
 
-// parseTestLogFile supports CSV or JSON test input
-func parseTestLogFile(filePath string) (map[string]interface{}, error) {
+// parseTestLogFile supports CSV, JSON, or evtx test input
+func parseTestLogFile(ctx context.Context, filePath string, stats *moduleStats) (map[string]interface{}, error) {
+	logger := logctx.FromContext(ctx)
+
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("could not open test log file: %v", err)
@@ -231,11 +488,45 @@ func parseTestLogFile(filePath string) (map[string]interface{}, error) {
 		if err := json.NewDecoder(file).Decode(&entries); err != nil {
 			return nil, fmt.Errorf("failed to parse JSON file: %v", err)
 		}
+	case ".evtx":
+		// parseEvtxFile opens the file itself via EvtQuery, so close our own handle
+		// first to avoid holding it open twice.
+		file.Close()
+		var err error
+		entries, err = parseEvtxFile(filePath, logger, stats)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse evtx file: %v", err)
+		}
 	default:
 		return nil, fmt.Errorf("unsupported file extension: %s", ext)
 	}
 
+	logger.Debugf("windows-logging: parsed %d entries from %s", len(entries), filePath)
 	return map[string]interface{}{
 		"test_logs": entries,
 	}, nil
 }
+
+// filterEntries drops entries whose Source or Message matches logFilter, applied
+// uniformly across the live and test paths so the same config silences chatty
+// sources in either mode.
+func filterEntries(entries []map[string]interface{}, logFilter string) ([]map[string]interface{}, error) {
+	if logFilter == "" {
+		return entries, nil
+	}
+	re, err := regexp.Compile(logFilter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log_filter regex: %w", err)
+	}
+
+	filtered := entries[:0]
+	for _, entry := range entries {
+		source, _ := entry["Source"].(string)
+		message, _ := entry["Message"].(string)
+		if re.MatchString(source) || re.MatchString(message) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered, nil
+}
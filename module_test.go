@@ -0,0 +1,68 @@
+package windowslogging
+
+import "testing"
+
+func TestFilterEntriesEmptyFilterPassesEverythingThrough(t *testing.T) {
+	entries := []map[string]interface{}{
+		{"Source": "SceCli", "Message": "noisy"},
+	}
+	got, err := filterEntries(entries, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected entries to pass through unchanged, got %d entries", len(got))
+	}
+}
+
+func TestFilterEntriesMatchesSourceOrMessage(t *testing.T) {
+	entries := []map[string]interface{}{
+		{"Source": "SceCli", "Message": "applied policy"},
+		{"Source": "Application Error", "Message": "noisy crash dump"},
+		{"Source": "Application Error", "Message": "clean entry"},
+	}
+	got, err := filterEntries(entries, "SceCli|noisy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected only the non-matching entry to survive, got %d entries", len(got))
+	}
+	if got[0]["Source"] != "Application Error" || got[0]["Message"] != "clean entry" {
+		t.Fatalf("unexpected surviving entry: %+v", got[0])
+	}
+}
+
+func TestFilterEntriesInvalidRegex(t *testing.T) {
+	if _, err := filterEntries(nil, "("); err == nil {
+		t.Fatal("expected an error for an invalid log_filter regex")
+	}
+}
+
+func TestToIntSliceConvertsFloat64Array(t *testing.T) {
+	got, err := toIntSlice([]interface{}{float64(4103), float64(4104)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []int{4103, 4104}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestToIntSliceRejectsNonArray(t *testing.T) {
+	if _, err := toIntSlice("not an array"); err == nil {
+		t.Fatal("expected an error when event_ids is not an array")
+	}
+}
+
+func TestToIntSliceRejectsNonNumericElements(t *testing.T) {
+	if _, err := toIntSlice([]interface{}{"4103"}); err == nil {
+		t.Fatal("expected an error when event_ids contains non-numeric elements")
+	}
+}
@@ -0,0 +1,125 @@
+package windowslogging
+
+import (
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// moduleStats holds the counters surfaced by the "stats" DoCommand verb and, when
+// Config.PrometheusAddr is set, the /metrics endpoint. Each counter is a CounterVec
+// keyed by "source" (the channel, log type, or file the entries came from) so
+// multi-source deployments can tell which one is generating events or errors.
+type moduleStats struct {
+	eventsReadTotal       *prometheus.CounterVec
+	parseErrorsTotal      *prometheus.CounterVec
+	powershellInvocations *prometheus.CounterVec
+	startTime             time.Time
+
+	lastErrorMu sync.Mutex
+	lastError   string
+}
+
+func newModuleStats(name string) *moduleStats {
+	labels := prometheus.Labels{"sensor": name}
+	return &moduleStats{
+		eventsReadTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "windows_logging_events_read_total",
+			Help:        "Total number of Windows event log entries read.",
+			ConstLabels: labels,
+		}, []string{"source"}),
+		parseErrorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "windows_logging_parse_errors_total",
+			Help:        "Total number of failures parsing event log output.",
+			ConstLabels: labels,
+		}, []string{"source"}),
+		powershellInvocations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "windows_logging_powershell_invocations_total",
+			Help:        "Total number of Get-EventLog PowerShell invocations.",
+			ConstLabels: labels,
+		}, []string{"source"}),
+		startTime: time.Now(),
+	}
+}
+
+func (m *moduleStats) addEventsRead(source string, n int) {
+	m.eventsReadTotal.WithLabelValues(source).Add(float64(n))
+}
+
+func (m *moduleStats) incParseErrors(source string) {
+	m.parseErrorsTotal.WithLabelValues(source).Inc()
+}
+
+func (m *moduleStats) incPowershellInvocations(source string) {
+	m.powershellInvocations.WithLabelValues(source).Inc()
+}
+
+func (m *moduleStats) setLastError(err error) {
+	if err == nil {
+		return
+	}
+	m.lastErrorMu.Lock()
+	defer m.lastErrorMu.Unlock()
+	m.lastError = err.Error()
+}
+
+// snapshot returns the current counters as a plain map, for the "stats" DoCommand
+// verb.
+func (m *moduleStats) snapshot() map[string]interface{} {
+	m.lastErrorMu.Lock()
+	lastError := m.lastError
+	m.lastErrorMu.Unlock()
+
+	return map[string]interface{}{
+		"events_read_total":      counterVecTotal(m.eventsReadTotal),
+		"events_read_by_source":  counterVecBySource(m.eventsReadTotal),
+		"parse_errors_total":     counterVecTotal(m.parseErrorsTotal),
+		"parse_errors_by_source": counterVecBySource(m.parseErrorsTotal),
+		"powershell_invocations": counterVecTotal(m.powershellInvocations),
+		"last_error":             lastError,
+		"uptime_seconds":         time.Since(m.startTime).Seconds(),
+	}
+}
+
+// collectors returns the counters to register with a prometheus.Registry.
+func (m *moduleStats) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.eventsReadTotal, m.parseErrorsTotal, m.powershellInvocations}
+}
+
+// counterVecBySource reads each source's current value out of a CounterVec, since
+// the client library doesn't expose a Get() accessor on the interface.
+func counterVecBySource(c *prometheus.CounterVec) map[string]float64 {
+	ch := make(chan prometheus.Metric, 16)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+
+	values := make(map[string]float64)
+	for metric := range ch {
+		var parsed dto.Metric
+		if err := metric.Write(&parsed); err != nil {
+			continue
+		}
+		source := ""
+		for _, label := range parsed.GetLabel() {
+			if label.GetName() == "source" {
+				source = label.GetValue()
+			}
+		}
+		values[source] = parsed.GetCounter().GetValue()
+	}
+	return values
+}
+
+// counterVecTotal sums a CounterVec's value across every source.
+func counterVecTotal(c *prometheus.CounterVec) float64 {
+	var total float64
+	for _, v := range counterVecBySource(c) {
+		total += v
+	}
+	return total
+}